@@ -1,31 +1,63 @@
 package main
 
 import (
-	"fmt"       // For printing to the console
-	"math"      // For mathematical operations like sine, cosine, and rounding
-	"math/rand" // For generating random numbers (e.g., firework positions, colors)
-	"os"        // For command-line arguments, environment variables, and exiting
-	"os/exec"   // For executing external commands (like 'stty' for terminal control)
-	"os/signal" // For handling system signals (like Ctrl+C)
-
-	// For converting strings to integers
-	"syscall" // For system calls (used with os/signal for specific signals)
-	"time"    // For time-related operations, especially for animation delays
-
-	"github.com/charmbracelet/x/term"
-)
+	"flag"         // For parsing command-line flags
+	"fmt"          // For formatting the per-frame view string
+	"math"         // For mathematical operations like sine, cosine, and rounding
+	"math/rand"    // For generating random numbers (e.g., firework positions, colors)
+	"os"           // For exiting on fatal errors and reading palettes.toml / COLORTERM
+	"regexp"       // For pulling quoted "h,s,l" anchors out of palettes.toml
+	"strconv"      // For parsing palettes.toml anchor components
+	"strings"      // For assembling the framebuffer string and parsing palettes.toml
+	"unicode/utf8" // For decoding the first rune of multi-byte trail characters
 
-// ANSI escape codes for terminal control.
-// These codes are used to manipulate the terminal's display,
-// such as clearing the screen, moving the cursor, and hiding/showing it.
-const (
-	clearScreen = "\033[2J"  // Clears the entire screen
-	cursorHome  = "\033[H"   // Moves the cursor to the top-left corner (1,1)
-	hideCursor  = "\033[?25l" // Hides the terminal cursor
-	showCursor  = "\033[?25h" // Shows the terminal cursor
-	resetColor  = "\033[0m"  // Resets all ANSI formatting (color, bold, etc.)
+	"time" // For time-related operations, especially for animation delays
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
+// palettesFile is the optional user palette definitions file, read from the
+// current working directory if present.
+const palettesFile = "palettes.toml"
+
+func main() {
+	countdown := flag.Int("countdown", 5, "seconds to count down before each launch (0 disables the countdown)")
+	rocketName := flag.String("rocket", defaultRocketSprite, "ASCII rocket sprite to use: classic, needle")
+	effectName := flag.String("effect", "random", "burst effect to use: peony, willow, ringshell, crackle, multistage, or random")
+	paletteName := flag.String("palette", defaultPaletteName, "truecolor gradient to use: sunset, aurora, ember, ice, or a name from palettes.toml")
+	flag.Parse()
+
+	loaded, err := loadPalettesFile(palettesFile)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Println("warning: ignoring palettes.toml:", err)
+	}
+	palette := resolvePalette(*paletteName, loaded)
+
+	p := tea.NewProgram(newModel(*countdown, *rocketName, *effectName, palette, supportsTrueColor()), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Println("error running fireworks:", err)
+		os.Exit(1)
+	}
+}
+
+// supportsTrueColor reports whether the terminal has advertised 24-bit color
+// support via COLORTERM, the de facto standard most terminal emulators use.
+func supportsTrueColor() bool {
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return true
+	default:
+		return false
+	}
+}
+
+// frameInterval is how often the animation advances one step. Bubble Tea
+// drives this via a repeating tea.Tick message rather than a blocking sleep.
+const frameInterval = 50 * time.Millisecond
+
+// ANSI escape code for resetting formatting after a colored cell.
+const resetColor = "\033[0m"
+
 // ANSI escape codes for various colors.
 // These are used to color the firework elements.
 var colors = []string{
@@ -38,202 +70,967 @@ var colors = []string{
 	"\033[37m", // White
 }
 
+// rocketSprites holds the built-in ASCII art variants selectable via --rocket.
+// Each sprite is drawn top-to-bottom (nose cone first) and anchored by its
+// bottom row, horizontally centered on the launch column, when rendered.
+var rocketSprites = map[string][]string{
+	"classic": {
+		" /\\ ",
+		"|  |",
+		"|  |",
+		"/||\\",
+	},
+	"needle": {
+		" ^ ",
+		"/|\\",
+		" | ",
+		"/ \\",
+	},
+}
+
+// defaultRocketSprite is used when --rocket names an unknown variant.
+const defaultRocketSprite = "classic"
+
+// exhaustChars are layered from closest-to-the-rocket to furthest, giving the
+// trail a tapering look as it stretches out behind a faster-climbing rocket.
+var exhaustChars = []string{"|", "!", "."}
+
+// HSL is a color expressed in hue/saturation/lightness, the space Palette
+// anchors are authored in since interpolating hue directly gives smoother
+// gradients than interpolating RGB channels.
+type HSL struct {
+	h, s, l float64 // Hue in degrees [0,360); saturation and lightness in [0,1]
+}
+
+// toRGB converts to 8-bit RGB for emitting as a truecolor ANSI sequence.
+func (c HSL) toRGB() (r, g, b uint8) {
+	h := math.Mod(c.h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s := clamp01(c.s)
+	l := clamp01(c.l)
+
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - chroma/2
+
+	var rf, gf, bf float64
+	switch {
+	case h < 60:
+		rf, gf, bf = chroma, x, 0
+	case h < 120:
+		rf, gf, bf = x, chroma, 0
+	case h < 180:
+		rf, gf, bf = 0, chroma, x
+	case h < 240:
+		rf, gf, bf = 0, x, chroma
+	case h < 300:
+		rf, gf, bf = x, 0, chroma
+	default:
+		rf, gf, bf = chroma, 0, x
+	}
+
+	return uint8((rf + m) * 255), uint8((gf + m) * 255), uint8((bf + m) * 255)
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// Palette is a named truecolor gradient, defined as a handful of HSL anchor
+// colors. Sample linearly interpolates between the two anchors t falls
+// between, so t=0 returns the first anchor and t=1 the last.
+type Palette struct {
+	Name    string
+	Anchors []HSL
+}
+
+func (p Palette) Sample(t float64) HSL {
+	switch len(p.Anchors) {
+	case 0:
+		return HSL{}
+	case 1:
+		return p.Anchors[0]
+	}
+
+	if t <= 0 {
+		return p.Anchors[0]
+	}
+	if t >= 1 {
+		return p.Anchors[len(p.Anchors)-1]
+	}
+
+	seg := t * float64(len(p.Anchors)-1)
+	i := int(seg)
+	frac := seg - float64(i)
+	a, b := p.Anchors[i], p.Anchors[i+1]
+	// Hue takes the shorter way around the color wheel, so e.g. 10 -> 350
+	// stays in the reds instead of sweeping back through green and cyan.
+	dh := math.Mod(b.h-a.h+540, 360) - 180
+	h := math.Mod(a.h+dh*frac+360, 360)
+	return HSL{h: h, s: lerp(a.s, b.s, frac), l: lerp(a.l, b.l, frac)}
+}
+
+// SampleANSI returns the 24-bit ANSI escape sequence for this palette at t.
+func (p Palette) SampleANSI(t float64) string {
+	r, g, b := p.Sample(t).toRGB()
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+}
+
+// defaultPaletteName is used when --palette names an unknown gradient.
+const defaultPaletteName = "ember"
+
+// builtinPalettes are the named gradients shipped with the binary. Anchors
+// run from a hot, near-white start toward a dim, saturated end; for Ember
+// and Sunset, aging a particle from t=0 to t=1 reproduces the hot-white ->
+// orange -> red -> dim fade of a real firework ember, while Aurora and Ice
+// fade through their own greens/violets and blues.
+var builtinPalettes = map[string]Palette{
+	"sunset": {Name: "Sunset", Anchors: []HSL{
+		{h: 50, s: 1.0, l: 0.95},
+		{h: 30, s: 0.9, l: 0.6},
+		{h: 10, s: 0.85, l: 0.45},
+		{h: 350, s: 0.6, l: 0.2},
+	}},
+	"aurora": {Name: "Aurora", Anchors: []HSL{
+		{h: 160, s: 0.8, l: 0.9},
+		{h: 180, s: 0.7, l: 0.55},
+		{h: 260, s: 0.6, l: 0.45},
+		{h: 290, s: 0.5, l: 0.2},
+	}},
+	"ember": {Name: "Ember", Anchors: []HSL{
+		{h: 45, s: 1.0, l: 0.95},
+		{h: 20, s: 0.9, l: 0.55},
+		{h: 0, s: 0.85, l: 0.4},
+		{h: 0, s: 0.7, l: 0.15},
+	}},
+	"ice": {Name: "Ice", Anchors: []HSL{
+		{h: 200, s: 0.6, l: 0.95},
+		{h: 210, s: 0.7, l: 0.7},
+		{h: 220, s: 0.75, l: 0.45},
+		{h: 230, s: 0.6, l: 0.2},
+	}},
+}
+
+// paletteAnchorRe extracts the quoted "h,s,l" entries from a palettes.toml
+// `colors = [...]` line.
+var paletteAnchorRe = regexp.MustCompile(`"([^"]+)"`)
+
+// loadPalettesFile reads a small, hand-rolled subset of TOML: `[name]`
+// section headers followed by a `colors = ["h,s,l", ...]` line. This covers
+// what a palette definition needs without pulling in a TOML dependency.
+func loadPalettesFile(path string) (map[string]Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	palettes := map[string]Palette{}
+	current := ""
+	for _, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			current = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+
+		if current == "" || !strings.HasPrefix(line, "colors") {
+			continue
+		}
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			continue
+		}
+
+		anchors, err := parsePaletteAnchors(line[eq+1:])
+		if err != nil {
+			return nil, fmt.Errorf("palette %q: %w", current, err)
+		}
+		palettes[current] = Palette{Name: current, Anchors: anchors}
+	}
+	return palettes, nil
+}
+
+func parsePaletteAnchors(value string) ([]HSL, error) {
+	matches := paletteAnchorRe.FindAllStringSubmatch(value, -1)
+	anchors := make([]HSL, 0, len(matches))
+	for _, m := range matches {
+		parts := strings.Split(m[1], ",")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid color anchor %q, want \"h,s,l\"", m[1])
+		}
+		h, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil {
+			return nil, err
+		}
+		s, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, err
+		}
+		l, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil {
+			return nil, err
+		}
+		anchors = append(anchors, HSL{h: h, s: s, l: l})
+	}
+	return anchors, nil
+}
+
+// resolvePalette looks up name among the palettes.toml overrides first, then
+// the built-ins, falling back to defaultPaletteName if name matches neither.
+func resolvePalette(name string, loaded map[string]Palette) Palette {
+	if p, ok := loaded[name]; ok {
+		return p
+	}
+	if p, ok := builtinPalettes[name]; ok {
+		return p
+	}
+	return builtinPalettes[defaultPaletteName]
+}
+
 // Particle represents a single element of the firework explosion.
 // It tracks its position, velocity, character, color, and remaining lifetime.
 type Particle struct {
-	x, y     float64 // Current position (using float for smoother sub-character movement)
-	vx, vy   float64 // Velocity in x and y directions
-	char     string  // The character to display for this particle (e.g., "*", "+")
-	color    string  // ANSI color code for the particle
-	lifetime int     // Number of frames the particle will remain visible
+	x, y          float64  // Current position (using float for smoother sub-character movement)
+	vx, vy        float64  // Velocity in x and y directions
+	char          string   // The character to display for this particle (e.g., "*", "+")
+	trail         []string // Optional fade sequence this particle's char steps through as it ages (WillowTrail)
+	color         string   // Explicit ANSI override; empty means "color by palette aging" (see colorForParticle)
+	lifetime      int      // Number of frames the particle will remain visible
+	birthLifetime int      // The lifetime value this particle was spawned with, used to compute its age fraction
+
+	gravity float64 // This particle's downward acceleration, in cells/sec^2
+	drag    float64 // This particle's velocity-proportional drag coefficient
+
+	stage int // Generation this particle belongs to: 0 for a burst's primary particles, 1 for anything spawned by OnDeath
 }
 
-func main() {
-	width, height, err := term.GetSize(0)
-	if err != nil {
-		fmt.Println("error detecting terminal size")
-		panic(err)
-	}
-
-	// Seed the random number generator.
-	// Using the current UnixNano time ensures different animations each run.
-	rand.Seed(time.Now().UnixNano())
-
-	// Set up a channel to listen for interrupt signals (like Ctrl+C).
-	// This allows for a graceful exit, restoring terminal settings.
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM) // Listen for Ctrl+C and termination signals
-
-	// Start a goroutine to handle signals.
-	// When a signal is received, it restores the terminal and exits.
-	go func() {
-		<-c // Block until a signal is received on channel 'c'
-		// Restore terminal settings before exiting.
-		enableInputBuffering() // Re-enable normal input buffering
-		fmt.Print(showCursor)  // Show the cursor again
-		fmt.Print(clearScreen) // Clear the screen
-		fmt.Print(cursorHome)  // Move cursor to home
-		os.Exit(0)             // Exit the program cleanly
-	}()
-
-	// Disable input buffering and hide the cursor.
-	// This prevents user input from appearing on the screen and allows for smooth animation.
-	disableInputBuffering()
-	fmt.Print(hideCursor)
-
-	// Ensure terminal settings are restored and cursor is shown when the program exits,
-	// regardless of how it exits (normal completion or panic).
-	defer enableInputBuffering() // This will run when main exits
-	defer fmt.Print(showCursor)  // This will run when main exits
-	defer fmt.Print(clearScreen) // This will run when main exits
-	defer fmt.Print(cursorHome)  // This will run when main exits
-
-	// Clear the screen once at the beginning.
-	fmt.Print(clearScreen)
-
-	// Main animation loop: continuously launch and explode fireworks.
-	for {
-		// Determine the launch position and explosion height for the firework.
-		// LaunchX is randomized to avoid edges.
-		launchX := rand.Intn(width-4) + 2 // X-coordinate for launch (2 units padding from edges)
-		launchY := height - 1            // Y-coordinate for launch (bottom of the terminal)
-		// ExplosionY is randomized to be in the upper half of the screen.
-		explosionY := rand.Intn(height/2) + height/4 // Explode between 1/4 and 3/4 of height
-
-		// Animate the rocket launching upwards.
-		rocketChar := "^" // Character representing the rocket
-		rocketColor := colors[rand.Intn(len(colors))] // Random color for the rocket
-		for y := launchY; y >= explosionY; y-- {
-			// Clear the entire screen and move cursor to home for each frame.
-			// This causes a slight flicker but simplifies animation logic.
-			fmt.Print(cursorHome)
-			fmt.Print(clearScreen)
-
-			// Draw the rocket at its current position.
-			moveTo(launchX, y)
-			fmt.Printf("%s%s%s", rocketColor, rocketChar, resetColor) // Print colored rocket
-
-			time.Sleep(50 * time.Millisecond) // Pause for a short duration to create animation frames
-		}
-
-		// Once the rocket reaches its explosion height, trigger the explosion.
-		explode(float64(launchX), float64(explosionY), width, height)
-
-		// Small delay before launching the next firework.
-		time.Sleep(time.Second)
-	}
-}
-
-// moveTo moves the terminal cursor to the specified (x, y) coordinates.
-// Terminal coordinates are 1-based, so (1,1) is the top-left corner.
-// Our internal coordinates are 0-based, so we add 1.
-func moveTo(x, y int) {
-	fmt.Printf("\033[%d;%dH", y+1, x+1)
-}
-
-// explode creates and animates the firework explosion.
-// It generates multiple particles that spread outwards and fade away.
-func explode(centerX, centerY float64, width, height int) {
-	numParticles := rand.Intn(30) + 20 // Generate between 20 and 50 particles
-	particles := make([]Particle, numParticles)
-	explosionColor := colors[rand.Intn(len(colors))] // All particles in this explosion share a color
-
-	// Characters to use for the explosion particles.
-	particleChars := []string{"*", "+", "o", "x", "."}
-
-	// Initialize each particle with a random direction, speed, and lifetime.
-	for i := 0; i < numParticles; i++ {
-		angle := rand.Float64() * 2 * math.Pi // Random angle for radial spread (0 to 2*PI radians)
-		speed := rand.Float64()*2 + 1.0       // Random initial speed (1.0 to 3.0)
+// willowFadeThreshold is the remaining-lifetime cutoff at or below which a
+// WillowTrail particle switches from its first trail char to its last,
+// mimicking the thinning look of a real willow trail as it falls.
+const willowFadeThreshold = 15
+
+// displayChar returns the rune this particle should be drawn with. Particles
+// with a trail (WillowTrail) fade from their first char to their last once
+// remaining lifetime reaches willowFadeThreshold; everything else just
+// draws its fixed char.
+func (p Particle) displayChar() rune {
+	if len(p.trail) == 0 {
+		return firstRune(p.char)
+	}
+	if p.lifetime > willowFadeThreshold {
+		return firstRune(p.trail[0])
+	}
+	return firstRune(p.trail[len(p.trail)-1])
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+// World carries the physics every Effect shares: a constant downward
+// gravity, a drag coefficient, and a wind function that can vary over time.
+// Effects read world.gravity/world.drag as a baseline when spawning their
+// particles (scaling them up or down for the look they want), and
+// World.Step applies the shared integration step to whatever a given
+// particle ended up with.
+type World struct {
+	gravity float64
+	drag    float64
+	wind    func(simTime float64) float64
+}
+
+// newWorld returns the World used for the whole session. Step integrates
+// continuously (it multiplies every acceleration by dt), whereas the
+// original model applied `p.vy += 0.1` once per tick with no dt factor at
+// all; at frameInterval's 50ms tick, reproducing that per-tick magnitude
+// means the per-second constants here need to be the old ones divided by
+// dt, i.e. multiplied by 1/0.05 = 20. Wind gets the same treatment so its
+// sideways drift stays visible relative to gravity instead of being
+// drowned out by the same dt factor.
+func newWorld() *World {
+	dt := frameInterval.Seconds()
+	return &World{
+		gravity: 0.1 / dt,
+		drag:    0.015 / dt,
+		wind: func(simTime float64) float64 {
+			return (0.1 / dt) * math.Sin(simTime*0.4)
+		},
+	}
+}
+
+// Step integrates every particle's velocity and position by dt, applying
+// this frame's wind and each particle's own gravity/drag.
+func (w *World) Step(particles []Particle, dt, simTime float64) {
+	wind := w.wind(simTime)
+	for i := range particles {
+		p := &particles[i]
+		ax := wind - p.drag*p.vx
+		ay := p.gravity - p.drag*p.vy
+		p.vx += ax * dt
+		p.vy += ay * dt
+		p.x += p.vx * dt
+		p.y += p.vy * dt
+	}
+}
+
+// Effect is a pluggable burst pattern: it builds the initial particles for
+// an explosion, and optionally reacts when one of its own particles dies.
+// Spawned particles leave color unset so they're aged through the session's
+// Palette (see colorForParticle); an effect only sets color explicitly when
+// it wants to bypass that, as Crackle's sparkles do.
+type Effect interface {
+	Name() string
+	Spawn(world *World, centerX, centerY float64) []Particle
+	OnDeath(p Particle) []Particle
+}
+
+// baseEffect gives effects that don't spawn children on death a free
+// no-op OnDeath implementation.
+type baseEffect struct{}
+
+func (baseEffect) OnDeath(Particle) []Particle { return nil }
+
+// radialBurst is the shared particle-generation routine behind most
+// effects: n particles fly out from the center at random angles and speeds
+// within [speedMin, speedMax], each assigned a random char from chars and a
+// random lifetime within [lifeMin, lifeMax).
+func radialBurst(n int, centerX, centerY float64, gravity, drag float64, chars []string, lifeMin, lifeMax int, speedMin, speedMax float64) []Particle {
+	particles := make([]Particle, n)
+	for i := 0; i < n; i++ {
+		angle := rand.Float64() * 2 * math.Pi
+		speed := rand.Float64()*(speedMax-speedMin) + speedMin
+		lifetime := rand.Intn(lifeMax-lifeMin) + lifeMin
 		particles[i] = Particle{
-			x:        centerX,
-			y:        centerY,
-			vx:       speed * math.Cos(angle),     // X velocity component
-			vy:       speed * math.Sin(angle) * 0.5, // Y velocity component (vertical spread is less pronounced)
-			char:     particleChars[rand.Intn(len(particleChars))], // Random character for the particle
-			color:    explosionColor,
-			lifetime: rand.Intn(20) + 10, // Particle visible for 10-30 frames
-		}
-	}
-
-	// Animate the explosion over several frames.
-	for frame := 0; frame < 60; frame++ { // Max 60 frames for the explosion animation
-		fmt.Print(cursorHome)  // Move cursor to home
-		fmt.Print(clearScreen) // Clear the screen for redrawing
-
-		aliveParticles := []Particle{} // Slice to hold particles that are still active
-		for i := range particles {
-			p := &particles[i] // Get a pointer to the current particle
-
-			if p.lifetime > 0 { // Only process particles that are still alive
-				// Update particle position based on velocity.
-				p.x += p.vx
-				p.y += p.vy
-				p.vy += 0.1 // Apply a small "gravity" effect, pulling particles downwards
-
-				// Convert float coordinates to integer coordinates for drawing on the terminal.
-				drawX := int(math.Round(p.x))
-				drawY := int(math.Round(p.y))
-
-				// Check if the particle is within the terminal bounds.
-				if drawX >= 0 && drawX < width && drawY >= 0 && drawY < height {
-					moveTo(drawX, drawY) // Move cursor to particle's position
-					fmt.Printf("%s%s%s", p.color, p.char, resetColor) // Print colored particle
-					p.lifetime--                                     // Decrease particle's remaining lifetime
-					aliveParticles = append(aliveParticles, *p)      // Add to the list of still active particles
-				}
-			}
+			x:             centerX,
+			y:             centerY,
+			vx:            speed * math.Cos(angle),
+			vy:            speed * math.Sin(angle) * 0.5,
+			char:          chars[rand.Intn(len(chars))],
+			lifetime:      lifetime,
+			birthLifetime: lifetime,
+			gravity:       gravity,
+			drag:          drag,
 		}
-		particles = aliveParticles // Update the main particles slice with only the alive ones
+	}
+	return particles
+}
+
+// PeonyBurst is the classic radial explosion: a uniform sphere of particles
+// falling under normal gravity. This is the original burst pattern, and the
+// one the random selector picks most often.
+type PeonyBurst struct{ baseEffect }
+
+func (PeonyBurst) Name() string { return "peony" }
 
-		// If all particles have faded or moved off-screen, and some initial frames have passed, break early.
-		if len(particles) == 0 && frame > 10 {
-			break
+func (PeonyBurst) Spawn(world *World, centerX, centerY float64) []Particle {
+	n := rand.Intn(30) + 20
+	return radialBurst(n, centerX, centerY, world.gravity, world.drag, []string{"*", "+", "o", "x", "."}, 10, 30, 1.0, 3.0)
+}
+
+// WillowTrail produces long-lived, slow-falling particles with a heavier
+// gravity and lighter drag than a peony burst, so they arc gracefully and
+// linger; each one fades from "." to "˙" as it nears the end of its life.
+type WillowTrail struct{ baseEffect }
+
+func (WillowTrail) Name() string { return "willow" }
+
+func (WillowTrail) Spawn(world *World, centerX, centerY float64) []Particle {
+	n := rand.Intn(20) + 15
+	particles := radialBurst(n, centerX, centerY, world.gravity*2.5, world.drag*0.3, []string{"."}, 40, 70, 0.5, 1.5)
+	for i := range particles {
+		particles[i].trail = []string{".", "˙"}
+	}
+	return particles
+}
+
+// RingShell spreads particles evenly around the circle at a fixed radial
+// speed rather than a randomized one, so the burst reads as a clean
+// expanding ring instead of a fuzzy sphere.
+type RingShell struct{ baseEffect }
+
+func (RingShell) Name() string { return "ringshell" }
+
+func (RingShell) Spawn(world *World, centerX, centerY float64) []Particle {
+	const speed = 2.2
+	n := rand.Intn(16) + 24
+	particles := make([]Particle, n)
+	for i := 0; i < n; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		lifetime := rand.Intn(10) + 20
+		particles[i] = Particle{
+			x:             centerX,
+			y:             centerY,
+			vx:            speed * math.Cos(angle),
+			vy:            speed * math.Sin(angle) * 0.5,
+			char:          "o",
+			lifetime:      lifetime,
+			birthLifetime: lifetime,
+			gravity:       world.gravity,
+			drag:          world.drag,
 		}
-		time.Sleep(80 * time.Millisecond) // Pause for animation speed
 	}
+	return particles
 }
 
-// disableInputBuffering attempts to put the terminal into "raw" mode.
-// This prevents user input from being echoed to the screen and allows direct cursor control.
-// WARNING: This function uses the 'stty' command, which is specific to Unix-like systems (Linux, macOS).
-// It will not work on Windows. For cross-platform terminal control in Go, consider
-// using a library like 'golang.org/x/term'.
-func disableInputBuffering() {
-	// 'cbreak' makes input available character by character without waiting for newline.
-	// 'min 1' ensures read operations return after at least one character.
-	cmd := exec.Command("stty", "-F", "/dev/tty", "cbreak", "min", "1")
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run()
-	if err != nil {
-		// fmt.Println("Warning: Could not disable input buffering:", err)
+// Crackle is a peony-like carrier burst whose particles, when they die, have
+// a chance to pop a few short-lived white sparkles at their last position.
+type Crackle struct{}
+
+func (Crackle) Name() string { return "crackle" }
+
+func (Crackle) Spawn(world *World, centerX, centerY float64) []Particle {
+	n := rand.Intn(20) + 15
+	return radialBurst(n, centerX, centerY, world.gravity, world.drag, []string{"*", "+", "o"}, 15, 25, 1.0, 2.5)
+}
+
+// crackleChance is the odds that any given carrier particle pops sparkles
+// when it dies, rather than just fading out quietly.
+const crackleChance = 0.4
+
+func (Crackle) OnDeath(p Particle) []Particle {
+	if p.stage != 0 || rand.Float64() > crackleChance {
+		return nil
 	}
+	n := rand.Intn(3) + 2
+	sparks := make([]Particle, n)
+	for i := range sparks {
+		angle := rand.Float64() * 2 * math.Pi
+		speed := rand.Float64()*0.8 + 0.3
+		lifetime := rand.Intn(4) + 4
+		sparks[i] = Particle{
+			x:             p.x,
+			y:             p.y,
+			vx:            speed * math.Cos(angle),
+			vy:            speed * math.Sin(angle),
+			char:          "*",
+			color:         "\033[37m", // White, regardless of the parent burst's palette
+			lifetime:      lifetime,
+			birthLifetime: lifetime,
+			gravity:       p.gravity,
+			drag:          p.drag,
+			stage:         1,
+		}
+	}
+	return sparks
+}
 
-	// '-echo' disables echoing of input characters to the terminal.
-	cmd = exec.Command("stty", "-F", "/dev/tty", "-echo")
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Run()
-	if err != nil {
-		// fmt.Println("Warning: Could not disable echo:", err)
+// MultiStage is a peony-like burst whose particles occasionally spawn a
+// secondary sub-explosion at their death position, giving the firework a
+// two-pop "stage separation" look.
+type MultiStage struct{}
+
+func (MultiStage) Name() string { return "multistage" }
+
+func (MultiStage) Spawn(world *World, centerX, centerY float64) []Particle {
+	n := rand.Intn(20) + 20
+	return radialBurst(n, centerX, centerY, world.gravity, world.drag, []string{"*", "+", "o", "x"}, 15, 30, 1.0, 2.5)
+}
+
+// multiStageChance is the odds that any given primary particle triggers a
+// sub-explosion when it dies.
+const multiStageChance = 0.3
+
+func (MultiStage) OnDeath(p Particle) []Particle {
+	if p.stage != 0 || rand.Float64() > multiStageChance {
+		return nil
 	}
+	sub := radialBurst(rand.Intn(10)+8, p.x, p.y, p.gravity, p.drag, []string{"*", "+", "."}, 8, 16, 0.6, 1.4)
+	for i := range sub {
+		sub[i].stage = 1
+	}
+	return sub
 }
 
-// enableInputBuffering attempts to restore the terminal to its normal "cooked" mode.
-// This re-enables input buffering and echoing.
-// WARNING: Like disableInputBuffering, this uses 'stty' and is Unix-specific.
-func enableInputBuffering() {
-	// 'cooked' restores normal line-buffered input.
-	// 'echo' re-enables echoing of input characters.
-	cmd := exec.Command("stty", "-F", "/dev/tty", "cooked", "echo")
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run()
-	if err != nil {
-		// fmt.Println("Warning: Could not re-enable input buffering:", err)
+// effects is the registry of every built-in Effect, in the order checked by
+// effectByName and weighted by effectWeights when choosing one at random.
+var effects = []Effect{
+	PeonyBurst{},
+	WillowTrail{},
+	RingShell{},
+	Crackle{},
+	MultiStage{},
+}
+
+// effectWeights favors the classic peony burst while still giving the other
+// effects regular appearances, so a session shows variety without any one
+// pattern dominating.
+var effectWeights = map[string]float64{
+	"peony":      0.35,
+	"willow":     0.2,
+	"ringshell":  0.2,
+	"crackle":    0.15,
+	"multistage": 0.1,
+}
+
+// effectByName looks up a built-in Effect by its Name(), returning nil if
+// name doesn't match one.
+func effectByName(name string) Effect {
+	for _, e := range effects {
+		if e.Name() == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// randomEffect picks an Effect according to effectWeights.
+func randomEffect() Effect {
+	total := 0.0
+	for _, e := range effects {
+		total += effectWeights[e.Name()]
+	}
+	r := rand.Float64() * total
+	for _, e := range effects {
+		r -= effectWeights[e.Name()]
+		if r <= 0 {
+			return e
+		}
+	}
+	return effects[0]
+}
+
+// phase enumerates the stages a single firework moves through.
+type phase int
+
+const (
+	phaseCountdown phase = iota
+	phaseLiftoff
+	phaseAscend
+	phaseExplode
+	phaseDone
+)
+
+// firework tracks one launch, from countdown through ascent to explosion.
+type firework struct {
+	phase phase
+
+	launchX, launchY, explosionY int
+	sprite                       []string
+	flatColor                    string  // Flat ANSI color for the rocket/exhaust, and for particles when truecolor is unavailable
+	palette                      Palette // Truecolor gradient particles age through when truecolor is available
+	effect                       Effect
+
+	countdownRemaining float64 // Seconds left in the countdown/liftoff phase
+	h, dhdt            float64 // Altitude climbed and climb rate, in cells and cells/sec
+
+	particles []Particle
+	frame     int // Frames spent in phaseExplode, used for the early-exit check
+}
+
+// model is the Bubble Tea state for the whole animation: terminal
+// dimensions, the active fireworks, and the user-adjustable knobs (spawn
+// rate, color offset, pause).
+type model struct {
+	width, height int
+
+	rocketName string
+	countdown  int
+	effectName string
+	palette    Palette
+	trueColor  bool
+
+	world   *World
+	simTime float64
+
+	spawnInterval      time.Duration
+	timeSinceLastSpawn time.Duration
+	paused             bool
+	colorOffset        int
+
+	fireworks []*firework
+
+	quitting bool
+}
+
+// tickMsg drives one animation step; Update schedules the next one each time
+// it handles this message, so the loop keeps running at frameInterval.
+type tickMsg time.Time
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(frameInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func newModel(countdown int, rocketName, effectName string, palette Palette, trueColor bool) model {
+	return model{
+		rocketName:    rocketName,
+		countdown:     countdown,
+		effectName:    effectName,
+		palette:       palette,
+		trueColor:     trueColor,
+		world:         newWorld(),
+		spawnInterval: time.Second,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tickCmd()
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case " ":
+			m.fireworks = append(m.fireworks, m.newInstantFirework())
+			return m, nil
+		case "p":
+			m.paused = !m.paused
+			return m, nil
+		case "+", "=":
+			m.spawnInterval = clampDuration(m.spawnInterval-200*time.Millisecond, 200*time.Millisecond, 5*time.Second)
+			return m, nil
+		case "-":
+			m.spawnInterval = clampDuration(m.spawnInterval+200*time.Millisecond, 200*time.Millisecond, 5*time.Second)
+			return m, nil
+		case "c":
+			m.colorOffset = (m.colorOffset + 1) % len(colors)
+			return m, nil
+		}
+		return m, nil
+
+	case tickMsg:
+		if !m.paused && m.width > 0 && m.height > 0 {
+			m.step(frameInterval.Seconds())
+		}
+		return m, tickCmd()
+	}
+
+	return m, nil
+}
+
+func (m model) View() string {
+	if m.quitting {
+		return ""
+	}
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	grid := newGrid(m.width, m.height)
+	for _, f := range m.fireworks {
+		f.draw(grid, m.width, m.height, m.trueColor)
+	}
+	return renderGrid(grid)
+}
+
+// step advances spawn timing and every in-flight firework by dt seconds, then
+// drops any fireworks that have finished exploding.
+func (m *model) step(dt float64) {
+	if m.width <= 4 || m.height <= 1 {
+		return
+	}
+
+	m.simTime += dt
+
+	m.timeSinceLastSpawn += time.Duration(dt * float64(time.Second))
+	if m.timeSinceLastSpawn >= m.spawnInterval {
+		m.timeSinceLastSpawn = 0
+		m.fireworks = append(m.fireworks, m.newFirework())
+	}
+
+	live := m.fireworks[:0]
+	for _, f := range m.fireworks {
+		f.step(dt, m.simTime, m.world, m.width, m.height)
+		if f.phase != phaseDone {
+			live = append(live, f)
+		}
+	}
+	m.fireworks = live
+}
+
+// chooseEffect resolves the --effect flag to a concrete Effect, falling back
+// to the weighted random selector for "random" or any unrecognized name.
+func (m *model) chooseEffect() Effect {
+	if e := effectByName(m.effectName); e != nil {
+		return e
+	}
+	return randomEffect()
+}
+
+// nextFlatColor returns the next entry from the basic 8-color palette,
+// honoring the offset the 'c' key cycles through. This is used for the
+// rocket/exhaust (which don't age through the truecolor Palette) and as the
+// particle fallback when the terminal doesn't advertise truecolor support.
+func (m *model) nextFlatColor() string {
+	return colors[(rand.Intn(len(colors))+m.colorOffset)%len(colors)]
+}
+
+// newFirework builds a firework that runs the full countdown (when enabled)
+// before ascending from a random pad position.
+func (m *model) newFirework() *firework {
+	launchX := rand.Intn(m.width-4) + 2
+	launchY := m.height - 1
+	explosionY := rand.Intn(m.height/2) + m.height/4
+
+	f := &firework{
+		launchX:    launchX,
+		launchY:    launchY,
+		explosionY: explosionY,
+		sprite:     rocketSpriteFor(m.rocketName),
+		flatColor:  m.nextFlatColor(),
+		palette:    m.palette,
+		effect:     m.chooseEffect(),
+	}
+
+	if m.countdown > 0 {
+		f.phase = phaseCountdown
+		f.countdownRemaining = float64(m.countdown)
+	} else {
+		f.phase = phaseAscend
+	}
+	return f
+}
+
+// newInstantFirework builds a firework that skips the countdown entirely, for
+// the space-bar "launch now" control.
+func (m *model) newInstantFirework() *firework {
+	f := m.newFirework()
+	f.phase = phaseAscend
+	return f
+}
+
+func rocketSpriteFor(name string) []string {
+	if sprite, ok := rocketSprites[name]; ok {
+		return sprite
+	}
+	return rocketSprites[defaultRocketSprite]
+}
+
+func clampDuration(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// step advances this firework by one frame, transitioning between phases as
+// timers expire or the rocket reaches its explosion altitude.
+func (f *firework) step(dt, simTime float64, world *World, width, height int) {
+	const d2hdt2 = 0.3 // Upward acceleration, in cells per second squared
+
+	switch f.phase {
+	case phaseCountdown:
+		f.countdownRemaining -= dt
+		if f.countdownRemaining <= 0 {
+			f.phase = phaseLiftoff
+			f.countdownRemaining = 0.4
+		}
+
+	case phaseLiftoff:
+		f.countdownRemaining -= dt
+		if f.countdownRemaining <= 0 {
+			f.phase = phaseAscend
+		}
+
+	case phaseAscend:
+		maxAltitude := float64(f.launchY - f.explosionY)
+		f.dhdt += d2hdt2 * dt
+		f.h += f.dhdt * dt
+		if f.h >= maxAltitude {
+			f.h = maxAltitude
+			f.phase = phaseExplode
+			f.particles = f.effect.Spawn(world, float64(f.launchX), float64(f.explosionY))
+			f.frame = 0
+		}
+
+	case phaseExplode:
+		f.advanceExplosion(world, dt, simTime, width, height)
+	}
+}
+
+// advanceExplosion integrates every live particle one frame via the shared
+// World physics, drops particles that have drifted off-screen, and rolls
+// any that just ran out of lifetime through the effect's OnDeath hook
+// (e.g. Crackle's sparkles, MultiStage's sub-bursts).
+func (f *firework) advanceExplosion(world *World, dt, simTime float64, width, height int) {
+	world.Step(f.particles, dt, simTime)
+
+	var alive, spawned []Particle
+	for _, p := range f.particles {
+		if p.lifetime <= 0 {
+			continue
+		}
+
+		drawX := int(math.Round(p.x))
+		drawY := int(math.Round(p.y))
+		if drawX < 0 || drawX >= width || drawY < 0 || drawY >= height {
+			continue
+		}
+
+		p.lifetime--
+		if p.lifetime > 0 {
+			alive = append(alive, p)
+		} else {
+			spawned = append(spawned, f.effect.OnDeath(p)...)
+		}
+	}
+	f.particles = append(alive, spawned...)
+	f.frame++
+
+	if len(f.particles) == 0 && f.frame > 10 {
+		f.phase = phaseDone
+	}
+}
+
+// draw paints this firework's current phase into the shared framebuffer.
+func (f *firework) draw(grid [][]cell, width, height int, trueColor bool) {
+	switch f.phase {
+	case phaseCountdown:
+		msg := fmt.Sprintf("%d...", int(math.Ceil(f.countdownRemaining)))
+		drawCentered(grid, msg, f.launchY-6, width)
+
+	case phaseLiftoff:
+		drawCentered(grid, "LIFTOFF!", f.launchY-6, width)
+
+	case phaseAscend:
+		y := f.launchY - int(f.h)
+		drawExhaustTrail(grid, f.launchX, y, f.dhdt, f.flatColor)
+		drawRocket(grid, f.sprite, f.flatColor, f.launchX, y)
+
+	case phaseExplode:
+		for _, p := range f.particles {
+			color := colorForParticle(p, f.palette, f.flatColor, trueColor)
+			setCell(grid, int(math.Round(p.x)), int(math.Round(p.y)), p.displayChar(), color)
+		}
+	}
+}
+
+// colorForParticle resolves the ANSI sequence to draw p with: an explicit
+// override if the particle (or its effect) set one, otherwise the
+// palette-sampled color for its age when truecolor is available, otherwise
+// the firework's flat fallback color.
+func colorForParticle(p Particle, palette Palette, flatColor string, trueColor bool) string {
+	if p.color != "" {
+		return p.color
+	}
+	if !trueColor {
+		return flatColor
+	}
+	age := 1 - float64(p.lifetime)/float64(max(p.birthLifetime, 1))
+	return palette.SampleANSI(age)
+}
+
+// cell is one position in the framebuffer: a rune to draw and the ANSI color
+// sequence (if any) it should be drawn with.
+type cell struct {
+	ch    rune
+	color string
+}
+
+func newGrid(width, height int) [][]cell {
+	grid := make([][]cell, height)
+	for y := range grid {
+		row := make([]cell, width)
+		for x := range row {
+			row[x] = cell{ch: ' '}
+		}
+		grid[y] = row
+	}
+	return grid
+}
+
+func setCell(grid [][]cell, x, y int, ch rune, color string) {
+	if y < 0 || y >= len(grid) || x < 0 || x >= len(grid[y]) {
+		return
+	}
+	grid[y][x] = cell{ch: ch, color: color}
+}
+
+// drawCentered writes msg horizontally centered at row y.
+func drawCentered(grid [][]cell, msg string, y, width int) {
+	x := (width - len(msg)) / 2
+	if x < 0 {
+		x = 0
+	}
+	for i, r := range msg {
+		setCell(grid, x+i, y, r, "")
+	}
+}
+
+// drawExhaustTrail renders a growing plume below the rocket whose length
+// scales with the current climb velocity.
+func drawExhaustTrail(grid [][]cell, x, rocketBottomY int, velocity float64, color string) {
+	trailLen := int(velocity * 2)
+	if trailLen > 8 {
+		trailLen = 8
+	}
+	for i := 1; i <= trailLen; i++ {
+		ch := exhaustChars[(i-1)*len(exhaustChars)/max(trailLen, 1)%len(exhaustChars)]
+		setCell(grid, x, rocketBottomY+i, rune(ch[0]), color)
+	}
+}
+
+// drawRocket renders the multi-line ASCII sprite with its bottom row anchored
+// at (x, bottomY), clipping any rows that fall off-screen.
+func drawRocket(grid [][]cell, sprite []string, color string, x, bottomY int) {
+	topY := bottomY - len(sprite) + 1
+	for i, line := range sprite {
+		y := topY + i
+		for j, r := range line {
+			setCell(grid, x-len(line)/2+j, y, r, color)
+		}
+	}
+}
+
+// renderGrid flattens the framebuffer into the string Bubble Tea will print.
+// Bubble Tea's own renderer already diffs this against the previous frame
+// and only rewrites the lines that changed, so the flicker the old
+// clearScreen-per-frame loop caused is gone as of the Bubble Tea port; what's
+// left for us to optimize is the string we hand it. Adjacent cells that
+// share a color are coalesced into a single SGR sequence instead of one per
+// cell, which keeps long runs of same-colored particles and rocket sprites
+// cheap to emit.
+func renderGrid(grid [][]cell) string {
+	var b strings.Builder
+	for y, row := range grid {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+
+		runColor := ""
+		for _, c := range row {
+			if c.color != runColor {
+				if runColor != "" {
+					b.WriteString(resetColor)
+				}
+				if c.color != "" {
+					b.WriteString(c.color)
+				}
+				runColor = c.color
+			}
+			b.WriteRune(c.ch)
+		}
+		if runColor != "" {
+			b.WriteString(resetColor)
+		}
 	}
+	return b.String()
 }